@@ -0,0 +1,18 @@
+package command
+
+import (
+	"github.com/caos/zitadel/internal/eventstore"
+)
+
+// Commands is the single entry point every write-side command (this
+// package's exported Add.../Change... methods) hangs off of, so
+// they all share one Eventstore connection instead of each opening their
+// own.
+type Commands struct {
+	eventstore *eventstore.Eventstore
+}
+
+// NewCommands wires Commands to es.
+func NewCommands(es *eventstore.Eventstore) *Commands {
+	return &Commands{eventstore: es}
+}
@@ -0,0 +1,122 @@
+package command
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/repository/idpconfig"
+)
+
+// validateJWTSigningAlgorithms rejects a JWT IDP configuration outright if
+// it allows an algorithm ZITADEL doesn't trust, so a bad config is caught
+// here - by AddOrgJWTIDPConfig/ChangeOrgJWTIDPConfig - instead of surfacing as a confusing
+// token-verification failure for every user who tries to log in through it.
+func validateJWTSigningAlgorithms(algorithms []domain.JWTSigningAlgorithm) error {
+	if len(algorithms) == 0 {
+		return errors.ThrowInvalidArgument(nil, "COMMAND-4m8fS", "Errors.IDPConfig.JWTConfig.SigningAlgorithms.Empty")
+	}
+	for _, algorithm := range algorithms {
+		if !algorithm.Valid() {
+			return errors.ThrowInvalidArgument(nil, "COMMAND-5m8fS", "Errors.IDPConfig.JWTConfig.SigningAlgorithms.Invalid")
+		}
+	}
+	return nil
+}
+
+// signingAlgorithmsEqual reports whether a and b allow exactly the same set
+// of algorithms, in the same order - used to tell a no-op resubmission of
+// the current allowlist apart from an actual change.
+func signingAlgorithmsEqual(a, b []domain.JWTSigningAlgorithm) bool {
+	return reflect.DeepEqual(a, b)
+}
+
+// addJWTConfig validates the signing-algorithm allowlist and builds the
+// JWTConfigAddedEvent for aggregate. It is the core of the org-level
+// AddOrgJWTIDPConfig command.
+func addJWTConfig(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	idpConfigID,
+	issuer,
+	keysEndpoint string,
+	jwksRefreshInterval,
+	jwksMaxAge time.Duration,
+	allowedSigningAlgorithms []domain.JWTSigningAlgorithm,
+	audience string,
+	requiredClaims map[string]string,
+	clockSkewLeeway time.Duration,
+) (*idpconfig.JWTConfigAddedEvent, error) {
+	if err := validateJWTSigningAlgorithms(allowedSigningAlgorithms); err != nil {
+		return nil, err
+	}
+	return idpconfig.NewJWTConfigAddedEvent(
+		ctx,
+		aggregate,
+		idpConfigID,
+		issuer,
+		keysEndpoint,
+		jwksRefreshInterval,
+		jwksMaxAge,
+		allowedSigningAlgorithms,
+		audience,
+		requiredClaims,
+		clockSkewLeeway,
+	), nil
+}
+
+// changeJWTConfig diffs the requested values against jwtConfigWriteModel
+// and builds a JWTConfigChangedEvent carrying only what actually changed.
+// It is the core of the org-level ChangeOrgJWTIDPConfig command.
+// A changed signing-algorithm allowlist is re-validated the same
+// way addJWTConfig validates a new one.
+func changeJWTConfig(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	jwtConfigWriteModel *JWTConfigWriteModel,
+	idpConfigID,
+	issuer,
+	keysEndpoint string,
+	jwksRefreshInterval,
+	jwksMaxAge time.Duration,
+	allowedSigningAlgorithms []domain.JWTSigningAlgorithm,
+	audience string,
+	requiredClaims map[string]string,
+	clockSkewLeeway time.Duration,
+) (*idpconfig.JWTConfigChangedEvent, error) {
+	changes := make([]idpconfig.JWTConfigChanges, 0)
+	if issuer != "" && issuer != jwtConfigWriteModel.Issuer {
+		changes = append(changes, idpconfig.ChangeIssuer(issuer))
+	}
+	if keysEndpoint != "" && keysEndpoint != jwtConfigWriteModel.KeysEndpoint {
+		changes = append(changes, idpconfig.ChangeKeysEndpoint(keysEndpoint))
+	}
+	if jwksRefreshInterval != 0 && jwksRefreshInterval != jwtConfigWriteModel.JWKSRefreshInterval {
+		changes = append(changes, idpconfig.ChangeJWKSRefreshInterval(jwksRefreshInterval))
+	}
+	if jwksMaxAge != 0 && jwksMaxAge != jwtConfigWriteModel.JWKSMaxAge {
+		changes = append(changes, idpconfig.ChangeJWKSMaxAge(jwksMaxAge))
+	}
+	if len(allowedSigningAlgorithms) > 0 && !signingAlgorithmsEqual(allowedSigningAlgorithms, jwtConfigWriteModel.AllowedSigningAlgorithms) {
+		if err := validateJWTSigningAlgorithms(allowedSigningAlgorithms); err != nil {
+			return nil, err
+		}
+		changes = append(changes, idpconfig.ChangeAllowedSigningAlgorithms(allowedSigningAlgorithms))
+	}
+	if audience != "" && audience != jwtConfigWriteModel.Audience {
+		changes = append(changes, idpconfig.ChangeAudience(audience))
+	}
+	if requiredClaims != nil && !reflect.DeepEqual(requiredClaims, jwtConfigWriteModel.RequiredClaims) {
+		changes = append(changes, idpconfig.ChangeRequiredClaims(requiredClaims))
+	}
+	if clockSkewLeeway != 0 && clockSkewLeeway != jwtConfigWriteModel.ClockSkewLeeway {
+		changes = append(changes, idpconfig.ChangeClockSkewLeeway(clockSkewLeeway))
+	}
+	if len(changes) == 0 {
+		return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-6m8fS", "Errors.NoChangesFound")
+	}
+	return idpconfig.NewJWTConfigChangedEvent(ctx, aggregate, idpConfigID, changes)
+}
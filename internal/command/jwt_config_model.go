@@ -1,6 +1,8 @@
 package command
 
 import (
+	"time"
+
 	"github.com/caos/zitadel/internal/domain"
 	"github.com/caos/zitadel/internal/eventstore"
 	"github.com/caos/zitadel/internal/repository/idpconfig"
@@ -13,6 +15,25 @@ type JWTConfigWriteModel struct {
 	Issuer       string
 	KeysEndpoint string
 	State        domain.IDPConfigState
+
+	JWKSRefreshInterval      time.Duration
+	JWKSMaxAge               time.Duration
+	AllowedSigningAlgorithms []domain.JWTSigningAlgorithm
+	Audience                 string
+	RequiredClaims           map[string]string
+	ClockSkewLeeway          time.Duration
+}
+
+// NewJWTConfigWriteModel returns an empty write model scoped to idpConfigID,
+// ready to be filled by the eventstore so ChangeOrgJWTIDPConfig has
+// something to diff the requested change against.
+func NewJWTConfigWriteModel(idpConfigID, resourceOwner string) *JWTConfigWriteModel {
+	return &JWTConfigWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   idpConfigID,
+			ResourceOwner: resourceOwner,
+		},
+	}
 }
 
 func (wm *JWTConfigWriteModel) Reduce() error {
@@ -39,6 +60,19 @@ func (wm *JWTConfigWriteModel) reduceConfigAddedEvent(e *idpconfig.JWTConfigAdde
 	wm.Issuer = e.Issuer
 	wm.KeysEndpoint = e.KeysEndpoint
 	wm.State = domain.IDPConfigStateActive
+
+	wm.JWKSRefreshInterval = e.JWKSRefreshInterval
+	wm.JWKSMaxAge = e.JWKSMaxAge
+	wm.Audience = e.Audience
+	wm.RequiredClaims = e.RequiredClaims
+	wm.ClockSkewLeeway = e.ClockSkewLeeway
+
+	// Events predating the allowed-algorithm allowlist carry none: default
+	// to what ZITADEL already accepted rather than rejecting every token.
+	wm.AllowedSigningAlgorithms = e.AllowedSigningAlgorithms
+	if len(wm.AllowedSigningAlgorithms) == 0 {
+		wm.AllowedSigningAlgorithms = domain.DefaultJWTSigningAlgorithms
+	}
 }
 
 func (wm *JWTConfigWriteModel) reduceConfigChangedEvent(e *idpconfig.JWTConfigChangedEvent) {
@@ -48,4 +82,22 @@ func (wm *JWTConfigWriteModel) reduceConfigChangedEvent(e *idpconfig.JWTConfigCh
 	if e.KeysEndpoint != nil {
 		wm.KeysEndpoint = *e.KeysEndpoint
 	}
+	if e.JWKSRefreshInterval != nil {
+		wm.JWKSRefreshInterval = *e.JWKSRefreshInterval
+	}
+	if e.JWKSMaxAge != nil {
+		wm.JWKSMaxAge = *e.JWKSMaxAge
+	}
+	if e.AllowedSigningAlgorithms != nil {
+		wm.AllowedSigningAlgorithms = *e.AllowedSigningAlgorithms
+	}
+	if e.Audience != nil {
+		wm.Audience = *e.Audience
+	}
+	if e.RequiredClaims != nil {
+		wm.RequiredClaims = *e.RequiredClaims
+	}
+	if e.ClockSkewLeeway != nil {
+		wm.ClockSkewLeeway = *e.ClockSkewLeeway
+	}
 }
@@ -0,0 +1,70 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/repository/idpconfig"
+)
+
+func TestJWTConfigWriteModel_reduceConfigAddedEvent(t *testing.T) {
+	aggregate := eventstore.NewAggregate("idp1", "org", "v1", "instance-id")
+
+	t.Run("defaults the signing algorithm allowlist for events that predate it", func(t *testing.T) {
+		wm := &JWTConfigWriteModel{}
+		wm.Events = []eventstore.Event{
+			idpconfig.NewJWTConfigAddedEvent(
+				context.Background(),
+				aggregate,
+				"idp1",
+				"https://issuer",
+				"https://issuer/keys",
+				0, 0, nil, "", nil, 0,
+			),
+		}
+
+		if err := wm.Reduce(); err != nil {
+			t.Fatalf("reduce: %v", err)
+		}
+
+		if len(wm.AllowedSigningAlgorithms) != 1 || wm.AllowedSigningAlgorithms[0] != domain.JWTSigningAlgorithmRS256 {
+			t.Errorf("expected default signing algorithm allowlist, got %v", wm.AllowedSigningAlgorithms)
+		}
+	})
+
+	t.Run("keeps the configured signing algorithm allowlist", func(t *testing.T) {
+		wm := &JWTConfigWriteModel{}
+		wm.Events = []eventstore.Event{
+			idpconfig.NewJWTConfigAddedEvent(
+				context.Background(),
+				aggregate,
+				"idp1",
+				"https://issuer",
+				"https://issuer/keys",
+				time.Hour,
+				24*time.Hour,
+				[]domain.JWTSigningAlgorithm{domain.JWTSigningAlgorithmES256},
+				"my-audience",
+				map[string]string{"aud": "my-audience"},
+				2*time.Minute,
+			),
+		}
+
+		if err := wm.Reduce(); err != nil {
+			t.Fatalf("reduce: %v", err)
+		}
+
+		if len(wm.AllowedSigningAlgorithms) != 1 || wm.AllowedSigningAlgorithms[0] != domain.JWTSigningAlgorithmES256 {
+			t.Errorf("expected configured signing algorithm allowlist, got %v", wm.AllowedSigningAlgorithms)
+		}
+		if wm.Audience != "my-audience" {
+			t.Errorf("unexpected audience: %s", wm.Audience)
+		}
+		if wm.ClockSkewLeeway != 2*time.Minute {
+			t.Errorf("unexpected clock skew leeway: %s", wm.ClockSkewLeeway)
+		}
+	})
+}
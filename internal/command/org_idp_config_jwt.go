@@ -0,0 +1,129 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/repository/org"
+)
+
+// AddOrgJWTIDPConfig adds a JWT IDP configuration to the org identified by
+// resourceOwner. addJWTConfig validates the signing-algorithm allowlist
+// before the event is ever written, so a misconfigured IDP is rejected here
+// instead of failing confusingly for every user who later tries to log in
+// through it.
+func (c *Commands) AddOrgJWTIDPConfig(
+	ctx context.Context,
+	resourceOwner,
+	idpConfigID,
+	issuer,
+	keysEndpoint string,
+	jwksRefreshInterval,
+	jwksMaxAge time.Duration,
+	allowedSigningAlgorithms []domain.JWTSigningAlgorithm,
+	audience string,
+	requiredClaims map[string]string,
+	clockSkewLeeway time.Duration,
+) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" || idpConfigID == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-7m8fS", "Errors.IDMissing")
+	}
+
+	existing := NewJWTConfigWriteModel(idpConfigID, resourceOwner)
+	if err := c.eventstore.FilterToQueryReducer(ctx, existing); err != nil {
+		return nil, err
+	}
+	if existing.State != domain.IDPConfigStateUnspecified {
+		return nil, errors.ThrowAlreadyExists(nil, "COMMAND-7m8fT", "Errors.IDPConfig.AlreadyExists")
+	}
+
+	orgAgg := org.NewAggregate(idpConfigID, resourceOwner)
+	event, err := addJWTConfig(
+		ctx,
+		&orgAgg.Aggregate,
+		idpConfigID,
+		issuer,
+		keysEndpoint,
+		jwksRefreshInterval,
+		jwksMaxAge,
+		allowedSigningAlgorithms,
+		audience,
+		requiredClaims,
+		clockSkewLeeway,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	pushedEvents, err := c.eventstore.PushEvents(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ObjectDetails{
+		Sequence:      pushedEvents[0].Sequence(),
+		EventDate:     pushedEvents[0].CreationDate(),
+		ResourceOwner: pushedEvents[0].Aggregate().ResourceOwner,
+	}, nil
+}
+
+// ChangeOrgJWTIDPConfig changes the JWT IDP configuration idpConfigID on
+// the org identified by resourceOwner, writing only the fields that
+// actually changed (see changeJWTConfig).
+func (c *Commands) ChangeOrgJWTIDPConfig(
+	ctx context.Context,
+	resourceOwner,
+	idpConfigID,
+	issuer,
+	keysEndpoint string,
+	jwksRefreshInterval,
+	jwksMaxAge time.Duration,
+	allowedSigningAlgorithms []domain.JWTSigningAlgorithm,
+	audience string,
+	requiredClaims map[string]string,
+	clockSkewLeeway time.Duration,
+) (*domain.ObjectDetails, error) {
+	if resourceOwner == "" || idpConfigID == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-8m8fS", "Errors.IDMissing")
+	}
+
+	existing := NewJWTConfigWriteModel(idpConfigID, resourceOwner)
+	if err := c.eventstore.FilterToQueryReducer(ctx, existing); err != nil {
+		return nil, err
+	}
+	if existing.State != domain.IDPConfigStateActive {
+		return nil, errors.ThrowNotFound(nil, "COMMAND-9m8fS", "Errors.IDPConfig.NotFound")
+	}
+
+	orgAgg := org.NewAggregate(idpConfigID, resourceOwner)
+	event, err := changeJWTConfig(
+		ctx,
+		&orgAgg.Aggregate,
+		existing,
+		idpConfigID,
+		issuer,
+		keysEndpoint,
+		jwksRefreshInterval,
+		jwksMaxAge,
+		allowedSigningAlgorithms,
+		audience,
+		requiredClaims,
+		clockSkewLeeway,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	pushedEvents, err := c.eventstore.PushEvents(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ObjectDetails{
+		Sequence:      pushedEvents[0].Sequence(),
+		EventDate:     pushedEvents[0].CreationDate(),
+		ResourceOwner: pushedEvents[0].Aggregate().ResourceOwner,
+	}, nil
+}
@@ -0,0 +1,29 @@
+package domain
+
+// JWTSigningAlgorithm is a JWS "alg" value a JWT IDP configuration may
+// allow for the tokens it verifies.
+type JWTSigningAlgorithm string
+
+const (
+	JWTSigningAlgorithmRS256 JWTSigningAlgorithm = "RS256"
+	JWTSigningAlgorithmES256 JWTSigningAlgorithm = "ES256"
+	JWTSigningAlgorithmEdDSA JWTSigningAlgorithm = "EdDSA"
+)
+
+// Valid reports whether alg is one ZITADEL is willing to accept for a JWT
+// IDP. Notably absent: "none" and the symmetric HS* family, which would let
+// anyone holding the (public) RSA/EC key re-sign a token by treating it as
+// an HMAC secret.
+func (alg JWTSigningAlgorithm) Valid() bool {
+	switch alg {
+	case JWTSigningAlgorithmRS256, JWTSigningAlgorithmES256, JWTSigningAlgorithmEdDSA:
+		return true
+	default:
+		return false
+	}
+}
+
+// DefaultJWTSigningAlgorithms is applied to JWT IDP configs that predate
+// the allowed-algorithm allowlist, so existing integrations keep verifying
+// tokens the way they always have instead of suddenly rejecting everything.
+var DefaultJWTSigningAlgorithms = []JWTSigningAlgorithm{JWTSigningAlgorithmRS256}
@@ -0,0 +1,143 @@
+// Package crdb translates the backend agnostic Operations produced by
+// reducers into CockroachDB SQL. It is the default handler.StatementBuilder
+// used by every projection unless the instance is configured to run its
+// projections against a document store (see the mongo package).
+package crdb
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+)
+
+// Builder is the crdb handler.StatementBuilder. It carries no state: the
+// table a Statement targets is always the projection's own name, passed in
+// at Execute time.
+type Builder struct{}
+
+// NewBuilder returns the crdb StatementBuilder.
+func NewBuilder() *Builder {
+	return &Builder{}
+}
+
+// init registers crdb as handler.DefaultStatementBuilder, the same way a
+// database/sql driver registers itself - so a projection's zero-value
+// handler.StatementHandler{} (as every projection's own reducer tests
+// construct) still produces real SQL Statements instead of panicking on a
+// nil Builder.
+func init() {
+	handler.DefaultStatementBuilder = NewBuilder()
+}
+
+func (*Builder) NewCreateStatement(event eventstore.Event, values []handler.Column) *handler.Statement {
+	return newStatement(event, handler.Operation{Type: handler.OpCreate, Values: values})
+}
+
+func (*Builder) NewUpdateStatement(event eventstore.Event, values, conditions []handler.Column) *handler.Statement {
+	return newStatement(event, handler.Operation{Type: handler.OpUpdate, Values: values, Conditions: conditions})
+}
+
+func (*Builder) NewDeleteStatement(event eventstore.Event, conditions []handler.Column) *handler.Statement {
+	return newStatement(event, handler.Operation{Type: handler.OpDelete, Conditions: conditions})
+}
+
+func (*Builder) NewMultiStatement(event eventstore.Event, ops ...func() handler.Operation) *handler.Statement {
+	operations := make([]handler.Operation, len(ops))
+	for i, op := range ops {
+		operations[i] = op()
+	}
+	return newStatement(event, operations...)
+}
+
+func newStatement(event eventstore.Event, operations ...handler.Operation) *handler.Statement {
+	return &handler.Statement{
+		AggregateType:    event.Aggregate().Type,
+		Sequence:         event.Sequence(),
+		PreviousSequence: event.PreviousAggregateSequence(),
+		Execute: func(ex handler.Executer, projectionName string) error {
+			for _, op := range operations {
+				query, args := translate(op, projectionName)
+				if _, err := ex.Exec(query, args...); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// translate renders a single Operation as CockroachDB SQL, mirroring the
+// tuple assignment syntax ("SET (a, b) = ($1, $2)") CockroachDB supports for
+// multi-column updates.
+func translate(op handler.Operation, table string) (string, []interface{}) {
+	switch op.Type {
+	case handler.OpCreate:
+		return translateCreate(op, table)
+	case handler.OpUpdate:
+		return translateUpdate(op, table)
+	case handler.OpDelete:
+		return translateDelete(op, table)
+	default:
+		return "", nil
+	}
+}
+
+func translateCreate(op handler.Operation, table string) (string, []interface{}) {
+	names := make([]string, len(op.Values))
+	placeholders := make([]string, len(op.Values))
+	args := make([]interface{}, len(op.Values))
+	for i, col := range op.Values {
+		names[i] = col.Name
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = col.Value
+	}
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+		table, strings.Join(names, ", "), strings.Join(placeholders, ", "))
+	return query, args
+}
+
+func translateUpdate(op handler.Operation, table string) (string, []interface{}) {
+	args := make([]interface{}, 0, len(op.Values)+len(op.Conditions))
+	i := 1
+
+	var set string
+	if len(op.Values) == 1 {
+		set = fmt.Sprintf("%s = $%d", op.Values[0].Name, i)
+		args = append(args, op.Values[0].Value)
+		i++
+	} else {
+		names := make([]string, len(op.Values))
+		placeholders := make([]string, len(op.Values))
+		for idx, col := range op.Values {
+			names[idx] = col.Name
+			placeholders[idx] = fmt.Sprintf("$%d", i)
+			args = append(args, col.Value)
+			i++
+		}
+		set = fmt.Sprintf("(%s) = (%s)", strings.Join(names, ", "), strings.Join(placeholders, ", "))
+	}
+
+	where, whereArgs := translateConditions(op.Conditions, i)
+	args = append(args, whereArgs...)
+
+	query := fmt.Sprintf("UPDATE %s SET %s WHERE %s", table, set, where)
+	return query, args
+}
+
+func translateDelete(op handler.Operation, table string) (string, []interface{}) {
+	where, args := translateConditions(op.Conditions, 1)
+	query := fmt.Sprintf("DELETE FROM %s WHERE %s", table, where)
+	return query, args
+}
+
+func translateConditions(conditions []handler.Column, startArg int) (string, []interface{}) {
+	clauses := make([]string, len(conditions))
+	args := make([]interface{}, len(conditions))
+	for i, cond := range conditions {
+		clauses[i] = fmt.Sprintf("(%s = $%d)", cond.Name, startArg+i)
+		args[i] = cond.Value
+	}
+	return strings.Join(clauses, " AND "), args
+}
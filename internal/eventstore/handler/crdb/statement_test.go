@@ -0,0 +1,103 @@
+package crdb
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+)
+
+func TestTranslate_create(t *testing.T) {
+	query, args := translate(handler.Operation{
+		Type: handler.OpCreate,
+		Values: []handler.Column{
+			handler.NewCol("user_id", "user-id"),
+			handler.NewCol("roles", []string{"role"}),
+		},
+	}, "projections.org_members3")
+
+	wantQuery := "INSERT INTO projections.org_members3 (user_id, roles) VALUES ($1, $2)"
+	if query != wantQuery {
+		t.Errorf("query = %q, want %q", query, wantQuery)
+	}
+	wantArgs := []interface{}{"user-id", []string{"role"}}
+	if !reflect.DeepEqual(args, wantArgs) {
+		t.Errorf("args = %v, want %v", args, wantArgs)
+	}
+}
+
+func TestTranslate_updateSingleColumn(t *testing.T) {
+	query, args := translate(handler.Operation{
+		Type:       handler.OpUpdate,
+		Values:     []handler.Column{handler.NewCol("roles", []string{"role"})},
+		Conditions: []handler.Column{handler.NewCol("user_id", "user-id")},
+	}, "projections.org_members3")
+
+	wantQuery := "UPDATE projections.org_members3 SET roles = $1 WHERE (user_id = $2)"
+	if query != wantQuery {
+		t.Errorf("query = %q, want %q", query, wantQuery)
+	}
+	wantArgs := []interface{}{[]string{"role"}, "user-id"}
+	if !reflect.DeepEqual(args, wantArgs) {
+		t.Errorf("args = %v, want %v", args, wantArgs)
+	}
+}
+
+// TestTranslate_updateMultiColumn covers the tuple SET shape org_member_test.go
+// asserts for org.MemberChangedType, including the two-condition
+// (user_id, org_id) WHERE clause reduceChanged relies on.
+func TestTranslate_updateMultiColumn(t *testing.T) {
+	query, args := translate(handler.Operation{
+		Type: handler.OpUpdate,
+		Values: []handler.Column{
+			handler.NewCol("roles", []string{"role", "changed"}),
+			handler.NewCol("sequence", uint64(15)),
+		},
+		Conditions: []handler.Column{
+			handler.NewCol("user_id", "user-id"),
+			handler.NewCol("org_id", "agg-id"),
+		},
+	}, "projections.org_members3")
+
+	wantQuery := "UPDATE projections.org_members3 SET (roles, sequence) = ($1, $2) WHERE (user_id = $3) AND (org_id = $4)"
+	if query != wantQuery {
+		t.Errorf("query = %q, want %q", query, wantQuery)
+	}
+	wantArgs := []interface{}{[]string{"role", "changed"}, uint64(15), "user-id", "agg-id"}
+	if !reflect.DeepEqual(args, wantArgs) {
+		t.Errorf("args = %v, want %v", args, wantArgs)
+	}
+}
+
+func TestTranslate_delete(t *testing.T) {
+	query, args := translate(handler.Operation{
+		Type: handler.OpDelete,
+		Conditions: []handler.Column{
+			handler.NewCol("user_id", "user-id"),
+		},
+	}, "projections.org_members3")
+
+	wantQuery := "DELETE FROM projections.org_members3 WHERE (user_id = $1)"
+	if query != wantQuery {
+		t.Errorf("query = %q, want %q", query, wantQuery)
+	}
+	wantArgs := []interface{}{"user-id"}
+	if !reflect.DeepEqual(args, wantArgs) {
+		t.Errorf("args = %v, want %v", args, wantArgs)
+	}
+}
+
+func TestTranslate_deleteTwoConditions(t *testing.T) {
+	query, _ := translate(handler.Operation{
+		Type: handler.OpDelete,
+		Conditions: []handler.Column{
+			handler.NewCol("user_id", "user-id"),
+			handler.NewCol("org_id", "agg-id"),
+		},
+	}, "projections.org_members3")
+
+	want := "DELETE FROM projections.org_members3 WHERE (user_id = $1) AND (org_id = $2)"
+	if query != want {
+		t.Errorf("query = %q, want %q", query, want)
+	}
+}
@@ -0,0 +1,166 @@
+// Package mongo translates the backend agnostic Operations produced by
+// reducers into MongoDB collection writes. Projections are unaware which
+// handler.StatementBuilder is active; operators opt into this one by
+// setting `Projections.Store: mongodb` in defaults.yaml instead of the
+// default CockroachDB store (see the crdb package).
+//
+// A projection's composite relational key (e.g. org_members' (user_id,
+// org_id)) is modeled as a compound _id: {"user_id": ..., "org_id": ...},
+// set when a document is inserted. Conditions are always applied as a
+// plain field filter with updateMany/deleteMany, whether they cover the
+// key columns or only part of them (e.g. reduceOrgRemoved's
+// "resource_owner = ..." owner-removed sweep); they are not turned into an
+// _id lookup.
+package mongo
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+)
+
+// Collection is the subset of *mongo.Collection the translator needs.
+// *mongo.Collection satisfies it as-is; unit tests substitute a fake
+// instead of requiring a live MongoDB instance.
+type Collection interface {
+	InsertOne(ctx context.Context, document interface{}, opts ...*options.InsertOneOptions) (*mongo.InsertOneResult, error)
+	UpdateMany(ctx context.Context, filter, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error)
+	DeleteMany(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error)
+}
+
+// Database is the subset of *mongo.Database the Builder needs, so tests can
+// fake it without spinning up a real server.
+type Database interface {
+	Collection(name string) Collection
+}
+
+// WrapDatabase adapts a real *mongo.Database to Database for NewBuilder.
+// *mongo.Collection already satisfies Collection, so this is a pure
+// type-level shim - the production path never touches the network
+// differently than calling *mongo.Database.Collection directly would.
+func WrapDatabase(db *mongo.Database) Database {
+	return realDatabase{db}
+}
+
+type realDatabase struct {
+	db *mongo.Database
+}
+
+func (r realDatabase) Collection(name string) Collection {
+	return r.db.Collection(name)
+}
+
+// Builder is the mongo handler.StatementBuilder.
+type Builder struct {
+	db Database
+}
+
+// NewBuilder returns a mongo StatementBuilder writing to db.
+func NewBuilder(db Database) *Builder {
+	return &Builder{db: db}
+}
+
+func (b *Builder) NewCreateStatement(event eventstore.Event, values []handler.Column) *handler.Statement {
+	return b.newStatement(event, handler.Operation{Type: handler.OpCreate, Values: values})
+}
+
+func (b *Builder) NewUpdateStatement(event eventstore.Event, values, conditions []handler.Column) *handler.Statement {
+	return b.newStatement(event, handler.Operation{Type: handler.OpUpdate, Values: values, Conditions: conditions})
+}
+
+func (b *Builder) NewDeleteStatement(event eventstore.Event, conditions []handler.Column) *handler.Statement {
+	return b.newStatement(event, handler.Operation{Type: handler.OpDelete, Conditions: conditions})
+}
+
+func (b *Builder) NewMultiStatement(event eventstore.Event, ops ...func() handler.Operation) *handler.Statement {
+	operations := make([]handler.Operation, len(ops))
+	for i, op := range ops {
+		operations[i] = op()
+	}
+	return b.newStatement(event, operations...)
+}
+
+func (b *Builder) newStatement(event eventstore.Event, operations ...handler.Operation) *handler.Statement {
+	return &handler.Statement{
+		AggregateType:    event.Aggregate().Type,
+		Sequence:         event.Sequence(),
+		PreviousSequence: event.PreviousAggregateSequence(),
+		Execute: func(_ handler.Executer, projectionName string) error {
+			ctx := context.Background()
+			collection := b.db.Collection(projectionName)
+			for _, op := range operations {
+				if err := apply(ctx, collection, op); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func apply(ctx context.Context, collection Collection, op handler.Operation) error {
+	switch op.Type {
+	case handler.OpCreate:
+		doc := bson.M{"_id": id(op.Values)}
+		for _, col := range op.Values {
+			doc[col.Name] = col.Value
+		}
+		_, err := collection.InsertOne(ctx, doc)
+		return err
+	case handler.OpUpdate:
+		_, err := collection.UpdateMany(ctx, filter(op.Conditions), bson.M{"$set": set(op.Values)})
+		return err
+	case handler.OpDelete:
+		_, err := collection.DeleteMany(ctx, filter(op.Conditions))
+		return err
+	default:
+		return fmt.Errorf("mongo: unknown operation type %d", op.Type)
+	}
+}
+
+// keyColumns lists the column names mongo treats as forming a document's
+// natural key, mirroring the composite primary keys relational projections
+// declare over the same columns (e.g. org_members' (user_id, org_id)).
+var keyColumns = map[string]bool{
+	"id":         true,
+	"user_id":    true,
+	"org_id":     true,
+	"project_id": true,
+}
+
+// id builds the compound _id mongo uses in place of a relational composite
+// primary key, from whichever of values is key-shaped.
+func id(values []handler.Column) bson.M {
+	compound := bson.M{}
+	for _, col := range values {
+		if keyColumns[col.Name] {
+			compound[col.Name] = col.Value
+		}
+	}
+	if len(compound) == 0 {
+		return nil
+	}
+	return compound
+}
+
+func filter(conditions []handler.Column) bson.M {
+	f := make(bson.M, len(conditions))
+	for _, cond := range conditions {
+		f[cond.Name] = cond.Value
+	}
+	return f
+}
+
+func set(values []handler.Column) bson.M {
+	s := make(bson.M, len(values))
+	for _, col := range values {
+		s[col.Name] = col.Value
+	}
+	return s
+}
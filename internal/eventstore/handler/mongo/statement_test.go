@@ -0,0 +1,150 @@
+package mongo
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+)
+
+// fakeCollection records every call made against it instead of talking to
+// a real MongoDB instance.
+type fakeCollection struct {
+	inserted []bson.M
+	updates  []struct{ filter, set bson.M }
+	deletes  []bson.M
+}
+
+func (f *fakeCollection) InsertOne(_ context.Context, document interface{}, _ ...*options.InsertOneOptions) (*mongo.InsertOneResult, error) {
+	f.inserted = append(f.inserted, document.(bson.M))
+	return nil, nil
+}
+
+func (f *fakeCollection) UpdateMany(_ context.Context, filter, update interface{}, _ ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	f.updates = append(f.updates, struct{ filter, set bson.M }{
+		filter: filter.(bson.M),
+		set:    update.(bson.M)["$set"].(bson.M),
+	})
+	return nil, nil
+}
+
+func (f *fakeCollection) DeleteMany(_ context.Context, filter interface{}, _ ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+	f.deletes = append(f.deletes, filter.(bson.M))
+	return nil, nil
+}
+
+func TestApply_create_buildsCompoundID(t *testing.T) {
+	collection := &fakeCollection{}
+
+	err := apply(context.Background(), collection, handler.Operation{
+		Type: handler.OpCreate,
+		Values: []handler.Column{
+			handler.NewCol("user_id", "user-id"),
+			handler.NewCol("org_id", "agg-id"),
+			handler.NewCol("roles", []string{"role"}),
+		},
+	})
+	if err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	if len(collection.inserted) != 1 {
+		t.Fatalf("want 1 insert, got %d", len(collection.inserted))
+	}
+	want := bson.M{
+		"_id":     bson.M{"user_id": "user-id", "org_id": "agg-id"},
+		"user_id": "user-id",
+		"org_id":  "agg-id",
+		"roles":   []string{"role"},
+	}
+	if !reflect.DeepEqual(collection.inserted[0], want) {
+		t.Errorf("inserted = %v, want %v", collection.inserted[0], want)
+	}
+}
+
+func TestApply_update(t *testing.T) {
+	collection := &fakeCollection{}
+
+	err := apply(context.Background(), collection, handler.Operation{
+		Type:       handler.OpUpdate,
+		Values:     []handler.Column{handler.NewCol("roles", []string{"role", "changed"})},
+		Conditions: []handler.Column{handler.NewCol("user_id", "user-id"), handler.NewCol("org_id", "agg-id")},
+	})
+	if err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	if len(collection.updates) != 1 {
+		t.Fatalf("want 1 update, got %d", len(collection.updates))
+	}
+	got := collection.updates[0]
+	if !reflect.DeepEqual(got.filter, bson.M{"user_id": "user-id", "org_id": "agg-id"}) {
+		t.Errorf("filter = %v", got.filter)
+	}
+	if !reflect.DeepEqual(got.set, bson.M{"roles": []string{"role", "changed"}}) {
+		t.Errorf("$set = %v", got.set)
+	}
+}
+
+func TestApply_delete(t *testing.T) {
+	collection := &fakeCollection{}
+
+	err := apply(context.Background(), collection, handler.Operation{
+		Type:       handler.OpDelete,
+		Conditions: []handler.Column{handler.NewCol("user_id", "user-id")},
+	})
+	if err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+
+	if len(collection.deletes) != 1 || !reflect.DeepEqual(collection.deletes[0], bson.M{"user_id": "user-id"}) {
+		t.Errorf("deletes = %v", collection.deletes)
+	}
+}
+
+// TestApply_orgRemoved_twoIndependentUpdates mirrors reduceOrgRemoved: two
+// updateMany calls against two unrelated filters (resource_owner vs
+// user_resource_owner), each flipping its own owner-removed flag.
+func TestApply_orgRemoved_twoIndependentUpdates(t *testing.T) {
+	collection := &fakeCollection{}
+	ctx := context.Background()
+
+	ops := []handler.Operation{
+		{
+			Type:       handler.OpUpdate,
+			Values:     []handler.Column{handler.NewCol("owner_removed", true)},
+			Conditions: []handler.Column{handler.NewCol("resource_owner", "agg-id")},
+		},
+		{
+			Type:       handler.OpUpdate,
+			Values:     []handler.Column{handler.NewCol("owner_removed_user", true)},
+			Conditions: []handler.Column{handler.NewCol("user_resource_owner", "agg-id")},
+		},
+	}
+	for _, op := range ops {
+		if err := apply(ctx, collection, op); err != nil {
+			t.Fatalf("apply: %v", err)
+		}
+	}
+
+	if len(collection.updates) != 2 {
+		t.Fatalf("want 2 independent updateMany calls, got %d", len(collection.updates))
+	}
+	if !reflect.DeepEqual(collection.updates[0].filter, bson.M{"resource_owner": "agg-id"}) {
+		t.Errorf("first filter = %v", collection.updates[0].filter)
+	}
+	if !reflect.DeepEqual(collection.updates[0].set, bson.M{"owner_removed": true}) {
+		t.Errorf("first $set = %v", collection.updates[0].set)
+	}
+	if !reflect.DeepEqual(collection.updates[1].filter, bson.M{"user_resource_owner": "agg-id"}) {
+		t.Errorf("second filter = %v", collection.updates[1].filter)
+	}
+	if !reflect.DeepEqual(collection.updates[1].set, bson.M{"owner_removed_user": true}) {
+		t.Errorf("second $set = %v", collection.updates[1].set)
+	}
+}
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"database/sql"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+// Column is a single column/field written or compared by an Operation. The
+// same Column is reused for both the SET/VALUES side of a write and the
+// WHERE side of a filter: a document store needs the same name/value pair
+// for either purpose.
+type Column struct {
+	Name  string
+	Value interface{}
+}
+
+// NewCol creates a Column.
+func NewCol(name string, value interface{}) Column {
+	return Column{Name: name, Value: value}
+}
+
+// OpType describes the kind of write an Operation performs.
+type OpType int
+
+const (
+	OpCreate OpType = iota
+	OpUpdate
+	OpDelete
+)
+
+// Operation is a backend agnostic description of a single write against a
+// projection: which columns to create/update and which columns identify
+// the row(s)/document(s) it applies to. Reducers (via a StatementBuilder)
+// produce Operations without ever knowing whether the configured
+// projection store is relational or document based; which table/collection
+// an Operation targets is only resolved once a StatementBuilder's Execute
+// closure actually runs, from the projection's own name.
+type Operation struct {
+	Type       OpType
+	Values     []Column
+	Conditions []Column
+}
+
+// Executer runs a single translated write. *sql.Tx satisfies it; the crdb
+// package's translator is built against exactly this interface so it can
+// be swapped out in tests.
+type Executer interface {
+	Exec(stmt string, args ...interface{}) (sql.Result, error)
+}
+
+// Exec is the translated, ready-to-run form of a Statement's Operations,
+// bound to the projection table/collection name it targets.
+type Exec func(ex Executer, projectionName string) error
+
+// Statement is produced by a reducer and later run against the projection
+// store configured for the instance.
+type Statement struct {
+	AggregateType    eventstore.AggregateType
+	Sequence         uint64
+	PreviousSequence uint64
+
+	Execute Exec
+}
+
+// StatementBuilder turns backend agnostic Operations into a Statement's
+// Execute closure. Every supported projection store (crdb, mongo, ...)
+// ships an implementation; a projection picks the one configured for the
+// instance instead of hard-coding SQL or Mongo calls itself.
+type StatementBuilder interface {
+	NewCreateStatement(event eventstore.Event, values []Column) *Statement
+	NewUpdateStatement(event eventstore.Event, values, conditions []Column) *Statement
+	NewDeleteStatement(event eventstore.Event, conditions []Column) *Statement
+	NewMultiStatement(event eventstore.Event, ops ...func() Operation) *Statement
+}
+
+// AddCreateStatement returns the step of a multi-step Statement (see
+// StatementBuilder.NewMultiStatement) that creates a row/document.
+func AddCreateStatement(values []Column) func() Operation {
+	return func() Operation {
+		return Operation{Type: OpCreate, Values: values}
+	}
+}
+
+// AddUpdateStatement returns the step of a multi-step Statement (see
+// StatementBuilder.NewMultiStatement) that updates matching rows/documents.
+func AddUpdateStatement(values, conditions []Column) func() Operation {
+	return func() Operation {
+		return Operation{Type: OpUpdate, Values: values, Conditions: conditions}
+	}
+}
+
+// AddDeleteStatement returns the step of a multi-step Statement (see
+// StatementBuilder.NewMultiStatement) that deletes matching rows/documents.
+func AddDeleteStatement(conditions []Column) func() Operation {
+	return func() Operation {
+		return Operation{Type: OpDelete, Conditions: conditions}
+	}
+}
+
+// DefaultStatementBuilder is used by a StatementHandler whose Builder was
+// left unset, so a zero-value StatementHandler{} - as every projection's
+// own reducer tests construct - still produces real Statements instead of
+// panicking on a nil Builder. The crdb package registers itself here via
+// an init func, the same way a database/sql driver registers itself; the
+// projection package imports it (for its side effect, in org_member.go) for
+// exactly this reason.
+var DefaultStatementBuilder StatementBuilder
+
+// StatementHandler is embedded by projections. It carries the eventstore
+// filter they reduce from, their own table/collection name, and the
+// StatementBuilder (crdb or mongo, selected from the instance's projection
+// store configuration) that turns their Operations into an Execute
+// closure. A nil Builder falls back to DefaultStatementBuilder.
+type StatementHandler struct {
+	Eventstore     *eventstore.Eventstore
+	ProjectionName string
+	Builder        StatementBuilder
+}
+
+func (h *StatementHandler) builder() StatementBuilder {
+	if h.Builder != nil {
+		return h.Builder
+	}
+	return DefaultStatementBuilder
+}
+
+// NewCreateStatement delegates to the configured StatementBuilder.
+func (h *StatementHandler) NewCreateStatement(event eventstore.Event, values []Column) *Statement {
+	return h.builder().NewCreateStatement(event, values)
+}
+
+// NewUpdateStatement delegates to the configured StatementBuilder.
+func (h *StatementHandler) NewUpdateStatement(event eventstore.Event, values, conditions []Column) *Statement {
+	return h.builder().NewUpdateStatement(event, values, conditions)
+}
+
+// NewDeleteStatement delegates to the configured StatementBuilder.
+func (h *StatementHandler) NewDeleteStatement(event eventstore.Event, conditions []Column) *Statement {
+	return h.builder().NewDeleteStatement(event, conditions)
+}
+
+// NewMultiStatement delegates to the configured StatementBuilder.
+func (h *StatementHandler) NewMultiStatement(event eventstore.Event, ops ...func() Operation) *Statement {
+	return h.builder().NewMultiStatement(event, ops...)
+}
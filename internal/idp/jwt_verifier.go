@@ -0,0 +1,89 @@
+// Package idp holds runtime logic the login UI and its IDP handlers share,
+// as opposed to internal/command (which only ever writes config) and
+// internal/query (which only ever reads it back).
+package idp
+
+import (
+	"time"
+
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/errors"
+)
+
+// JWTVerifier enforces the validation rules stored on a JWT IDP config
+// against a token actually presented at login, so a misconfigured
+// algorithm, audience or required claim is rejected consistently on every
+// login instead of relying on whatever the underlying JWT library defaults
+// to.
+type JWTVerifier struct {
+	AllowedSigningAlgorithms []domain.JWTSigningAlgorithm
+	Audience                 string
+	RequiredClaims           map[string]string
+	ClockSkewLeeway          time.Duration
+}
+
+// NewJWTVerifier builds a JWTVerifier from the IDP config fields the login
+// UI projection (see internal/query) read for idpConfigID.
+func NewJWTVerifier(allowedSigningAlgorithms []domain.JWTSigningAlgorithm, audience string, requiredClaims map[string]string, clockSkewLeeway time.Duration) *JWTVerifier {
+	return &JWTVerifier{
+		AllowedSigningAlgorithms: allowedSigningAlgorithms,
+		Audience:                 audience,
+		RequiredClaims:           requiredClaims,
+		ClockSkewLeeway:          clockSkewLeeway,
+	}
+}
+
+// VerifyAlgorithm rejects any "alg" not on the configured allowlist.
+func (v *JWTVerifier) VerifyAlgorithm(alg string) error {
+	for _, allowed := range v.AllowedSigningAlgorithms {
+		if string(allowed) == alg {
+			return nil
+		}
+	}
+	return errors.ThrowPermissionDenied(nil, "IDP-2m9g1", "Errors.IDPConfig.JWTConfig.SigningAlgorithm.NotAllowed")
+}
+
+// VerifyAudience requires aud to contain the configured audience. An empty
+// configured audience matches anything, so configs that predate this field
+// keep accepting whatever audience the token carries.
+func (v *JWTVerifier) VerifyAudience(aud []string) error {
+	if v.Audience == "" {
+		return nil
+	}
+	for _, a := range aud {
+		if a == v.Audience {
+			return nil
+		}
+	}
+	return errors.ThrowPermissionDenied(nil, "IDP-2m9g2", "Errors.IDPConfig.JWTConfig.Audience.NotMatched")
+}
+
+// VerifyClaims requires every configured RequiredClaims entry to be present
+// in claims with exactly the configured value.
+func (v *JWTVerifier) VerifyClaims(claims map[string]interface{}) error {
+	for key, want := range v.RequiredClaims {
+		got, ok := claims[key]
+		if !ok || got != want {
+			return errors.ThrowPermissionDenied(nil, "IDP-2m9g3", "Errors.IDPConfig.JWTConfig.RequiredClaim.Missing")
+		}
+	}
+	return nil
+}
+
+// VerifyNotExpired reports an error once exp, allowing for the configured
+// clock-skew leeway, is in the past relative to now.
+func (v *JWTVerifier) VerifyNotExpired(exp, now time.Time) error {
+	if now.After(exp.Add(v.ClockSkewLeeway)) {
+		return errors.ThrowPermissionDenied(nil, "IDP-2m9g4", "Errors.IDPConfig.JWTConfig.Token.Expired")
+	}
+	return nil
+}
+
+// VerifyNotBefore reports an error while nbf, allowing for the configured
+// clock-skew leeway, is still in the future relative to now.
+func (v *JWTVerifier) VerifyNotBefore(nbf, now time.Time) error {
+	if now.Add(v.ClockSkewLeeway).Before(nbf) {
+		return errors.ThrowPermissionDenied(nil, "IDP-2m9g5", "Errors.IDPConfig.JWTConfig.Token.NotYetValid")
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/query/projection"
+)
+
+// IAMMemberAuditEntry is a single row of the iam_member_audit_log
+// projection, mirroring OrgMemberAuditEntry for iam.Member* events. There is
+// no AggregateID: an instance has exactly one IAM aggregate.
+type IAMMemberAuditEntry struct {
+	InstanceID   string
+	Sequence     uint64
+	CreationDate time.Time
+	EventType    string
+	Actor        string
+	TargetUserID string
+	RolesAdded   []string
+	RolesRemoved []string
+}
+
+// IAMMemberAuditSearchQueries filters a ListIAMMemberAuditLog call. Every
+// field is optional; unset fields are not applied as a filter.
+type IAMMemberAuditSearchQueries struct {
+	UserID string
+	Actor  string
+	Since  time.Time
+	Until  time.Time
+}
+
+func (q IAMMemberAuditSearchQueries) toWhere() (string, []interface{}) {
+	var clauses []string
+	var args []interface{}
+
+	if q.UserID != "" {
+		args = append(args, q.UserID)
+		clauses = append(clauses, fmt.Sprintf("%s = $%d", projection.IAMMemberAuditTargetUserCol, len(args)))
+	}
+	if q.Actor != "" {
+		args = append(args, q.Actor)
+		clauses = append(clauses, fmt.Sprintf("%s = $%d", projection.IAMMemberAuditActorCol, len(args)))
+	}
+	if !q.Since.IsZero() {
+		args = append(args, q.Since)
+		clauses = append(clauses, fmt.Sprintf("%s >= $%d", projection.IAMMemberAuditCreationDate, len(args)))
+	}
+	if !q.Until.IsZero() {
+		args = append(args, q.Until)
+		clauses = append(clauses, fmt.Sprintf("%s <= $%d", projection.IAMMemberAuditCreationDate, len(args)))
+	}
+
+	if len(clauses) == 0 {
+		return "", nil
+	}
+	return "WHERE " + strings.Join(clauses, " AND "), args
+}
+
+// IAMMemberAuditEntries is the result of ListIAMMemberAuditLog: every
+// matching entry; it is not paged.
+type IAMMemberAuditEntries struct {
+	SearchResponse
+	Entries []*IAMMemberAuditEntry
+}
+
+// ListIAMMemberAuditLog reads the iam_member_audit_log projection, filtered
+// by the criteria in queries and ordered by sequence, oldest entry first.
+func ListIAMMemberAuditLog(ctx context.Context, db *sql.DB, queries IAMMemberAuditSearchQueries) (*IAMMemberAuditEntries, error) {
+	where, args := queries.toWhere()
+	stmt := fmt.Sprintf(
+		`SELECT instance_id, sequence, creation_date, event_type, actor_id, target_user_id, roles_added, roles_removed
+		 FROM %s %s ORDER BY sequence`,
+		projection.IAMMemberAuditProjectionTable, where,
+	)
+
+	rows, err := db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := &IAMMemberAuditEntries{}
+	for rows.Next() {
+		entry := new(IAMMemberAuditEntry)
+		var rolesAdded, rolesRemoved database.StringArray
+		if err := rows.Scan(
+			&entry.InstanceID,
+			&entry.Sequence,
+			&entry.CreationDate,
+			&entry.EventType,
+			&entry.Actor,
+			&entry.TargetUserID,
+			&rolesAdded,
+			&rolesRemoved,
+		); err != nil {
+			return nil, err
+		}
+		entry.RolesAdded = rolesAdded
+		entry.RolesRemoved = rolesRemoved
+		result.Entries = append(result.Entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	result.Count = uint64(len(result.Entries))
+	return result, nil
+}
@@ -0,0 +1,65 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+// jwtIDPConfigsTable is the projection the login UI reads a JWT IDP's
+// config from, independently of internal/command's own write model: the
+// login flow never has (and never needs) eventstore access, only the
+// fields it must enforce via internal/idp.JWTVerifier.
+const jwtIDPConfigsTable = "projections.idp_jwt_configs"
+
+// JWTIDPConfig is the subset of a JWT IDP's configuration the login UI
+// needs to build an internal/idp.JWTVerifier for it.
+type JWTIDPConfig struct {
+	IDPConfigID              string
+	Issuer                   string
+	KeysEndpoint             string
+	AllowedSigningAlgorithms []domain.JWTSigningAlgorithm
+	Audience                 string
+	RequiredClaims           map[string]string
+	ClockSkewLeeway          time.Duration
+}
+
+// JWTIDPConfigByID reads the JWT IDP config the login UI enforces against
+// for idpConfigID.
+func JWTIDPConfigByID(ctx context.Context, db *sql.DB, idpConfigID string) (*JWTIDPConfig, error) {
+	row := db.QueryRowContext(ctx,
+		`SELECT idp_config_id, issuer, keys_endpoint, allowed_signing_algorithms, audience, required_claims, clock_skew_leeway
+		 FROM `+jwtIDPConfigsTable+` WHERE idp_config_id = $1`,
+		idpConfigID,
+	)
+
+	config := new(JWTIDPConfig)
+	var allowedSigningAlgorithms database.StringArray
+	var requiredClaims []byte
+	if err := row.Scan(
+		&config.IDPConfigID,
+		&config.Issuer,
+		&config.KeysEndpoint,
+		&allowedSigningAlgorithms,
+		&config.Audience,
+		&requiredClaims,
+		&config.ClockSkewLeeway,
+	); err != nil {
+		return nil, err
+	}
+
+	config.AllowedSigningAlgorithms = make([]domain.JWTSigningAlgorithm, len(allowedSigningAlgorithms))
+	for i, alg := range allowedSigningAlgorithms {
+		config.AllowedSigningAlgorithms[i] = domain.JWTSigningAlgorithm(alg)
+	}
+	if len(requiredClaims) > 0 {
+		if err := json.Unmarshal(requiredClaims, &config.RequiredClaims); err != nil {
+			return nil, err
+		}
+	}
+	return config, nil
+}
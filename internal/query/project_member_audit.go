@@ -0,0 +1,119 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/query/projection"
+)
+
+// ProjectMemberAuditEntry is a single row of the project_member_audit_log
+// projection, mirroring OrgMemberAuditEntry for project.Member* events.
+type ProjectMemberAuditEntry struct {
+	InstanceID   string
+	AggregateID  string
+	Sequence     uint64
+	CreationDate time.Time
+	EventType    string
+	Actor        string
+	TargetUserID string
+	RolesAdded   []string
+	RolesRemoved []string
+}
+
+// ProjectMemberAuditSearchQueries filters a ListProjectMemberAuditLog call.
+// Every field is optional; unset fields are not applied as a filter.
+type ProjectMemberAuditSearchQueries struct {
+	ProjectID string
+	UserID    string
+	Actor     string
+	Since     time.Time
+	Until     time.Time
+}
+
+func (q ProjectMemberAuditSearchQueries) toWhere() (string, []interface{}) {
+	var clauses []string
+	var args []interface{}
+
+	if q.ProjectID != "" {
+		args = append(args, q.ProjectID)
+		clauses = append(clauses, fmt.Sprintf("%s = $%d", projection.ProjectMemberAuditAggregateIDCol, len(args)))
+	}
+	if q.UserID != "" {
+		args = append(args, q.UserID)
+		clauses = append(clauses, fmt.Sprintf("%s = $%d", projection.ProjectMemberAuditTargetUserCol, len(args)))
+	}
+	if q.Actor != "" {
+		args = append(args, q.Actor)
+		clauses = append(clauses, fmt.Sprintf("%s = $%d", projection.ProjectMemberAuditActorCol, len(args)))
+	}
+	if !q.Since.IsZero() {
+		args = append(args, q.Since)
+		clauses = append(clauses, fmt.Sprintf("%s >= $%d", projection.ProjectMemberAuditCreationDate, len(args)))
+	}
+	if !q.Until.IsZero() {
+		args = append(args, q.Until)
+		clauses = append(clauses, fmt.Sprintf("%s <= $%d", projection.ProjectMemberAuditCreationDate, len(args)))
+	}
+
+	if len(clauses) == 0 {
+		return "", nil
+	}
+	return "WHERE " + strings.Join(clauses, " AND "), args
+}
+
+// ProjectMemberAuditEntries is the result of ListProjectMemberAuditLog:
+// every matching entry; it is not paged.
+type ProjectMemberAuditEntries struct {
+	SearchResponse
+	Entries []*ProjectMemberAuditEntry
+}
+
+// ListProjectMemberAuditLog reads the project_member_audit_log projection,
+// filtered by the criteria in queries and ordered by sequence, oldest entry
+// first.
+func ListProjectMemberAuditLog(ctx context.Context, db *sql.DB, queries ProjectMemberAuditSearchQueries) (*ProjectMemberAuditEntries, error) {
+	where, args := queries.toWhere()
+	stmt := fmt.Sprintf(
+		`SELECT instance_id, aggregate_id, sequence, creation_date, event_type, actor_id, target_user_id, roles_added, roles_removed
+		 FROM %s %s ORDER BY sequence`,
+		projection.ProjectMemberAuditProjectionTable, where,
+	)
+
+	rows, err := db.QueryContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := &ProjectMemberAuditEntries{}
+	for rows.Next() {
+		entry := new(ProjectMemberAuditEntry)
+		var rolesAdded, rolesRemoved database.StringArray
+		if err := rows.Scan(
+			&entry.InstanceID,
+			&entry.AggregateID,
+			&entry.Sequence,
+			&entry.CreationDate,
+			&entry.EventType,
+			&entry.Actor,
+			&entry.TargetUserID,
+			&rolesAdded,
+			&rolesRemoved,
+		); err != nil {
+			return nil, err
+		}
+		entry.RolesAdded = rolesAdded
+		entry.RolesRemoved = rolesRemoved
+		result.Entries = append(result.Entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	result.Count = uint64(len(result.Entries))
+	return result, nil
+}
@@ -0,0 +1,37 @@
+package projection
+
+// RoleDiff is the result of comparing a membership's role set before and
+// after a change, shared by every *_audit projection (org/project/IAM
+// members today; user and IDP config changes are expected to reuse it)
+// so "what changed" is computed the same way everywhere instead of being
+// re-derived per event type.
+type RoleDiff struct {
+	Added   []string
+	Removed []string
+}
+
+// DiffRoles computes which roles were added and which were removed going
+// from before to after.
+func DiffRoles(before, after []string) RoleDiff {
+	beforeSet := make(map[string]bool, len(before))
+	for _, role := range before {
+		beforeSet[role] = true
+	}
+	afterSet := make(map[string]bool, len(after))
+	for _, role := range after {
+		afterSet[role] = true
+	}
+
+	diff := RoleDiff{}
+	for _, role := range after {
+		if !beforeSet[role] {
+			diff.Added = append(diff.Added, role)
+		}
+	}
+	for _, role := range before {
+		if !afterSet[role] {
+			diff.Removed = append(diff.Removed, role)
+		}
+	}
+	return diff
+}
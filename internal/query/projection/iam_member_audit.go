@@ -0,0 +1,130 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/repository/iam"
+)
+
+const (
+	IAMMemberAuditProjectionTable = "projections.iam_member_audit_log"
+
+	IAMMemberAuditInstanceIDCol   = "instance_id"
+	IAMMemberAuditSequenceCol     = "sequence"
+	IAMMemberAuditCreationDate    = "creation_date"
+	IAMMemberAuditEventTypeCol    = "event_type"
+	IAMMemberAuditActorCol        = "actor_id"
+	IAMMemberAuditTargetUserCol   = "target_user_id"
+	IAMMemberAuditRolesAddedCol   = "roles_added"
+	IAMMemberAuditRolesRemovedCol = "roles_removed"
+)
+
+// iamMemberAuditProjection mirrors orgMemberAuditProjection for
+// iam.Member* events. There is no org-removed equivalent here: an
+// instance's IAM aggregate is never deleted out from under its own
+// memberships.
+type iamMemberAuditProjection struct {
+	handler.StatementHandler
+}
+
+func (p *iamMemberAuditProjection) reduceAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*iam.MemberAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-6n8f1", "reduce.wrong.event.type %s", iam.MemberAddedEventType)
+	}
+
+	return p.NewCreateStatement(e, p.entryColumns(e, e.UserID, iam.MemberAddedEventType, e.Roles, nil))
+}
+
+func (p *iamMemberAuditProjection) reduceChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*iam.MemberChangedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-6n8f2", "reduce.wrong.event.type %s", iam.MemberChangedEventType)
+	}
+
+	before, err := p.previousRoles(context.Background(), e.Aggregate().ID, e.UserID, e.Sequence())
+	if err != nil {
+		return nil, err
+	}
+	diff := DiffRoles(before, e.Roles)
+
+	return p.NewCreateStatement(e, p.entryColumns(e, e.UserID, iam.MemberChangedEventType, diff.Added, diff.Removed))
+}
+
+func (p *iamMemberAuditProjection) reduceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*iam.MemberRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-6n8f3", "reduce.wrong.event.type %s", iam.MemberRemovedEventType)
+	}
+
+	before, err := p.previousRoles(context.Background(), e.Aggregate().ID, e.UserID, e.Sequence())
+	if err != nil {
+		return nil, err
+	}
+
+	return p.NewCreateStatement(e, p.entryColumns(e, e.UserID, iam.MemberRemovedEventType, nil, before))
+}
+
+func (p *iamMemberAuditProjection) reduceCascadeRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*iam.MemberCascadeRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-6n8f4", "reduce.wrong.event.type %s", iam.MemberCascadeRemovedEventType)
+	}
+
+	before, err := p.previousRoles(context.Background(), e.Aggregate().ID, e.UserID, e.Sequence())
+	if err != nil {
+		return nil, err
+	}
+
+	return p.NewCreateStatement(e, p.entryColumns(e, e.UserID, iam.MemberCascadeRemovedEventType, nil, before))
+}
+
+func (p *iamMemberAuditProjection) entryColumns(event eventstore.Event, targetUserID string, eventType eventstore.EventType, rolesAdded, rolesRemoved []string) []handler.Column {
+	return []handler.Column{
+		handler.NewCol(IAMMemberAuditInstanceIDCol, event.Aggregate().InstanceID),
+		handler.NewCol(IAMMemberAuditSequenceCol, event.Sequence()),
+		handler.NewCol(IAMMemberAuditCreationDate, event.CreationDate()),
+		handler.NewCol(IAMMemberAuditEventTypeCol, eventType),
+		handler.NewCol(IAMMemberAuditActorCol, event.EditorUser()),
+		handler.NewCol(IAMMemberAuditTargetUserCol, targetUserID),
+		handler.NewCol(IAMMemberAuditRolesAddedCol, database.StringArray(rolesAdded)),
+		handler.NewCol(IAMMemberAuditRolesRemovedCol, database.StringArray(rolesRemoved)),
+	}
+}
+
+// previousRoles mirrors orgMemberAuditProjection.previousRoles: it replays
+// the IAM aggregate's own member events, scoped by AggregateIDs like the
+// org/project equivalents rather than relying on there only ever being one.
+func (p *iamMemberAuditProjection) previousRoles(ctx context.Context, aggregateID, userID string, beforeSequence uint64) ([]string, error) {
+	events, err := p.Eventstore.Filter(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(iam.AggregateType).
+		AggregateIDs(aggregateID).
+		Builder(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var roles []string
+	for _, event := range events {
+		if event.Sequence() >= beforeSequence {
+			continue
+		}
+		switch e := event.(type) {
+		case *iam.MemberAddedEvent:
+			if e.UserID == userID {
+				roles = e.Roles
+			}
+		case *iam.MemberChangedEvent:
+			if e.UserID == userID {
+				roles = e.Roles
+			}
+		}
+	}
+	return roles, nil
+}
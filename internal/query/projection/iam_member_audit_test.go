@@ -0,0 +1,163 @@
+package projection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/iam"
+)
+
+func TestIAMMemberAuditProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "iam.MemberAddedType",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(iam.MemberAddedEventType),
+					iam.AggregateType,
+					[]byte(`{
+					"userId": "user-id",
+					"roles": ["role"]
+				}`),
+				), iam.MemberAddedEventMapper),
+			},
+			reduce: (&iamMemberAuditProjection{}).reduceAdded,
+			want: wantReduce{
+				aggregateType:    iam.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				projection:       IAMMemberAuditProjectionTable,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.iam_member_audit_log (instance_id, sequence, creation_date, event_type, actor_id, target_user_id, roles_added, roles_removed) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								uint64(15),
+								anyArg{},
+								iam.MemberAddedEventType,
+								anyArg{},
+								"user-id",
+								database.StringArray{"role"},
+								database.StringArray(nil),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "iam.MemberChangedType",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(iam.MemberChangedEventType),
+					iam.AggregateType,
+					[]byte(`{
+					"userId": "user-id",
+					"roles": ["role", "changed"]
+				}`),
+				), iam.MemberChangedEventMapper),
+			},
+			reduce: (&iamMemberAuditProjection{
+				StatementHandler: getStatementHandlerWithFilters(
+					iam.NewMemberAddedEvent(context.Background(),
+						&iam.NewAggregate("agg-id").Aggregate,
+						"user-id",
+						"role",
+					),
+				)(t)}).reduceChanged,
+			want: wantReduce{
+				aggregateType:    iam.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				projection:       IAMMemberAuditProjectionTable,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.iam_member_audit_log (instance_id, sequence, creation_date, event_type, actor_id, target_user_id, roles_added, roles_removed) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								uint64(15),
+								anyArg{},
+								iam.MemberChangedEventType,
+								anyArg{},
+								"user-id",
+								database.StringArray{"changed"},
+								database.StringArray(nil),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "iam.MemberRemovedType",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(iam.MemberRemovedEventType),
+					iam.AggregateType,
+					[]byte(`{
+					"userId": "user-id"
+				}`),
+				), iam.MemberRemovedEventMapper),
+			},
+			reduce: (&iamMemberAuditProjection{
+				StatementHandler: getStatementHandlerWithFilters(
+					iam.NewMemberAddedEvent(context.Background(),
+						&iam.NewAggregate("agg-id").Aggregate,
+						"user-id",
+						"role",
+					),
+				)(t)}).reduceRemoved,
+			want: wantReduce{
+				aggregateType:    iam.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				projection:       IAMMemberAuditProjectionTable,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.iam_member_audit_log (instance_id, sequence, creation_date, event_type, actor_id, target_user_id, roles_added, roles_removed) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								uint64(15),
+								anyArg{},
+								iam.MemberRemovedEventType,
+								anyArg{},
+								"user-id",
+								database.StringArray(nil),
+								database.StringArray{"role"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if _, ok := err.(errors.InvalidArgument); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, tt.want)
+		})
+	}
+}
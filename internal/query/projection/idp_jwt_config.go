@@ -0,0 +1,103 @@
+package projection
+
+import (
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/repository/idpconfig"
+)
+
+const (
+	IDPJWTConfigProjectionTable = "projections.idp_jwt_configs"
+
+	IDPJWTConfigIDPConfigIDCol              = "idp_config_id"
+	IDPJWTConfigInstanceIDCol               = "instance_id"
+	IDPJWTConfigIssuerCol                   = "issuer"
+	IDPJWTConfigKeysEndpointCol             = "keys_endpoint"
+	IDPJWTConfigAllowedSigningAlgorithmsCol = "allowed_signing_algorithms"
+	IDPJWTConfigAudienceCol                 = "audience"
+	IDPJWTConfigRequiredClaimsCol           = "required_claims"
+	IDPJWTConfigClockSkewLeewayCol          = "clock_skew_leeway"
+)
+
+// idpJWTConfigProjection reduces idpconfig.JWTConfig* events into the
+// idp_jwt_configs table query.JWTIDPConfigByID reads from - the fields the
+// login UI needs to build an internal/idp.JWTVerifier, independently of
+// internal/command's own JWTConfigWriteModel.
+type idpJWTConfigProjection struct {
+	handler.StatementHandler
+}
+
+func (p *idpJWTConfigProjection) reduceAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*idpconfig.JWTConfigAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-7n8f1", "reduce.wrong.event.type %s", idpconfig.JWTConfigAddedEventType)
+	}
+
+	requiredClaims, err := json.Marshal(e.RequiredClaims)
+	if err != nil {
+		return nil, err
+	}
+
+	// Events predating the allowlist carry none: store the default, as
+	// command.JWTConfigWriteModel does, so the login UI keeps accepting them.
+	allowedSigningAlgorithms := e.AllowedSigningAlgorithms
+	if len(allowedSigningAlgorithms) == 0 {
+		allowedSigningAlgorithms = domain.DefaultJWTSigningAlgorithms
+	}
+
+	return p.NewCreateStatement(e, []handler.Column{
+		handler.NewCol(IDPJWTConfigIDPConfigIDCol, e.IDPConfigID),
+		handler.NewCol(IDPJWTConfigInstanceIDCol, e.Aggregate().InstanceID),
+		handler.NewCol(IDPJWTConfigIssuerCol, e.Issuer),
+		handler.NewCol(IDPJWTConfigKeysEndpointCol, e.KeysEndpoint),
+		handler.NewCol(IDPJWTConfigAllowedSigningAlgorithmsCol, allowedSigningAlgorithms),
+		handler.NewCol(IDPJWTConfigAudienceCol, e.Audience),
+		handler.NewCol(IDPJWTConfigRequiredClaimsCol, requiredClaims),
+		handler.NewCol(IDPJWTConfigClockSkewLeewayCol, e.ClockSkewLeeway),
+	}), nil
+}
+
+// reduceChanged only writes the columns the event actually carries a value
+// for, mirroring command.changeJWTConfig only ever including the fields
+// that changed.
+func (p *idpJWTConfigProjection) reduceChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*idpconfig.JWTConfigChangedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-7n8f2", "reduce.wrong.event.type %s", idpconfig.JWTConfigChangedEventType)
+	}
+
+	var columns []handler.Column
+	if e.Issuer != nil {
+		columns = append(columns, handler.NewCol(IDPJWTConfigIssuerCol, *e.Issuer))
+	}
+	if e.KeysEndpoint != nil {
+		columns = append(columns, handler.NewCol(IDPJWTConfigKeysEndpointCol, *e.KeysEndpoint))
+	}
+	if e.AllowedSigningAlgorithms != nil {
+		columns = append(columns, handler.NewCol(IDPJWTConfigAllowedSigningAlgorithmsCol, *e.AllowedSigningAlgorithms))
+	}
+	if e.Audience != nil {
+		columns = append(columns, handler.NewCol(IDPJWTConfigAudienceCol, *e.Audience))
+	}
+	if e.RequiredClaims != nil {
+		requiredClaims, err := json.Marshal(*e.RequiredClaims)
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, handler.NewCol(IDPJWTConfigRequiredClaimsCol, requiredClaims))
+	}
+	if e.ClockSkewLeeway != nil {
+		columns = append(columns, handler.NewCol(IDPJWTConfigClockSkewLeewayCol, *e.ClockSkewLeeway))
+	}
+	if len(columns) == 0 {
+		return nil, errors.ThrowPreconditionFailed(nil, "PROJE-7n8f3", "Errors.NoChangesFound")
+	}
+
+	return p.NewUpdateStatement(e, columns, []handler.Column{
+		handler.NewCol(IDPJWTConfigIDPConfigIDCol, e.IDPConfigID),
+	}), nil
+}
@@ -0,0 +1,175 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	// registers crdb as handler.DefaultStatementBuilder, so a projection's
+	// zero-value handler.StatementHandler{} still builds real Statements.
+	_ "github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+const (
+	OrgMemberProjectionTable = "projections.org_members3"
+
+	OrgMemberUserIDCol            = "user_id"
+	OrgMemberUserResourceOwnerCol = "user_resource_owner"
+	OrgMemberOwnerRemovedUserCol  = "owner_removed_user"
+	OrgMemberRolesCol             = "roles"
+	OrgMemberCreationDateCol      = "creation_date"
+	OrgMemberChangeDateCol        = "change_date"
+	OrgMemberSequenceCol          = "sequence"
+	OrgMemberResourceOwnerCol     = "resource_owner"
+	OrgMemberInstanceIDCol        = "instance_id"
+	OrgMemberOwnerRemovedCol      = "owner_removed"
+	OrgMemberOrgIDCol             = "org_id"
+)
+
+// orgMemberProjection reduces org.Member* events into the org_members
+// table/collection. Like every projection, it never talks SQL or Mongo
+// directly: it builds handler.Operations and hands them to the
+// handler.StatementBuilder configured for the instance (see the crdb and
+// mongo packages).
+type orgMemberProjection struct {
+	handler.StatementHandler
+}
+
+func (p *orgMemberProjection) reduceAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.MemberAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-2m9f1", "reduce.wrong.event.type %s", org.MemberAddedEventType)
+	}
+
+	userResourceOwner, err := p.userResourceOwner(context.Background(), e.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.NewCreateStatement(e, []handler.Column{
+		handler.NewCol(OrgMemberUserIDCol, e.UserID),
+		handler.NewCol(OrgMemberUserResourceOwnerCol, userResourceOwner),
+		handler.NewCol(OrgMemberOwnerRemovedUserCol, false),
+		handler.NewCol(OrgMemberRolesCol, database.StringArray(e.Roles)),
+		handler.NewCol(OrgMemberCreationDateCol, e.CreationDate()),
+		handler.NewCol(OrgMemberChangeDateCol, e.CreationDate()),
+		handler.NewCol(OrgMemberSequenceCol, e.Sequence()),
+		handler.NewCol(OrgMemberResourceOwnerCol, e.Aggregate().ResourceOwner),
+		handler.NewCol(OrgMemberInstanceIDCol, e.Aggregate().InstanceID),
+		handler.NewCol(OrgMemberOwnerRemovedCol, false),
+		handler.NewCol(OrgMemberOrgIDCol, e.Aggregate().ID),
+	}), nil
+}
+
+func (p *orgMemberProjection) reduceChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.MemberChangedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-2m9f2", "reduce.wrong.event.type %s", org.MemberChangedEventType)
+	}
+
+	return p.NewUpdateStatement(e,
+		[]handler.Column{
+			handler.NewCol(OrgMemberRolesCol, database.StringArray(e.Roles)),
+			handler.NewCol(OrgMemberChangeDateCol, e.CreationDate()),
+			handler.NewCol(OrgMemberSequenceCol, e.Sequence()),
+		},
+		[]handler.Column{
+			handler.NewCol(OrgMemberUserIDCol, e.UserID),
+			handler.NewCol(OrgMemberOrgIDCol, e.Aggregate().ID),
+		},
+	), nil
+}
+
+func (p *orgMemberProjection) reduceCascadeRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.MemberCascadeRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-2m9f3", "reduce.wrong.event.type %s", org.MemberCascadeRemovedEventType)
+	}
+
+	return p.NewDeleteStatement(e, []handler.Column{
+		handler.NewCol(OrgMemberUserIDCol, e.UserID),
+		handler.NewCol(OrgMemberOrgIDCol, e.Aggregate().ID),
+	}), nil
+}
+
+func (p *orgMemberProjection) reduceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.MemberRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-2m9f4", "reduce.wrong.event.type %s", org.MemberRemovedEventType)
+	}
+
+	return p.NewDeleteStatement(e, []handler.Column{
+		handler.NewCol(OrgMemberUserIDCol, e.UserID),
+		handler.NewCol(OrgMemberOrgIDCol, e.Aggregate().ID),
+	}), nil
+}
+
+func (p *orgMemberProjection) reduceUserRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.UserRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-2m9f5", "reduce.wrong.event.type %s", user.UserRemovedType)
+	}
+
+	return p.NewDeleteStatement(e, []handler.Column{
+		handler.NewCol(OrgMemberUserIDCol, e.Aggregate().ID),
+	}), nil
+}
+
+// reduceOrgRemoved flags every row the removed org owns, instead of
+// deleting it: org_members rows the org owns directly (resource_owner) and
+// rows belonging to members that happen to be users of the removed org
+// (user_resource_owner) are two independent sets, so this needs two
+// update Operations rather than one.
+func (p *orgMemberProjection) reduceOrgRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-2m9f6", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+
+	return p.NewMultiStatement(e,
+		handler.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(OrgMemberChangeDateCol, e.CreationDate()),
+				handler.NewCol(OrgMemberSequenceCol, e.Sequence()),
+				handler.NewCol(OrgMemberOwnerRemovedCol, true),
+			},
+			[]handler.Column{
+				handler.NewCol(OrgMemberResourceOwnerCol, e.Aggregate().ID),
+			},
+		),
+		handler.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(OrgMemberChangeDateCol, e.CreationDate()),
+				handler.NewCol(OrgMemberSequenceCol, e.Sequence()),
+				handler.NewCol(OrgMemberOwnerRemovedUserCol, true),
+			},
+			[]handler.Column{
+				handler.NewCol(OrgMemberUserResourceOwnerCol, e.Aggregate().ID),
+			},
+		),
+	), nil
+}
+
+// userResourceOwner looks up the resource owner of the user aggregate
+// userID belongs to, so org_members can be filtered/flagged by it
+// (user_resource_owner) independently of the membership's own org
+// (resource_owner) once that user's org is removed.
+func (p *orgMemberProjection) userResourceOwner(ctx context.Context, userID string) (string, error) {
+	events, err := p.Eventstore.Filter(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(userID).
+		Builder(),
+	)
+	if err != nil {
+		return "", err
+	}
+	if len(events) == 0 {
+		return "", errors.ThrowNotFound(nil, "PROJE-2m9f7", "Errors.User.NotFound")
+	}
+	return events[0].Aggregate().ResourceOwner, nil
+}
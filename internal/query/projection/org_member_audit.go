@@ -0,0 +1,149 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+const (
+	OrgMemberAuditProjectionTable = "projections.org_member_audit_log"
+
+	OrgMemberAuditInstanceIDCol   = "instance_id"
+	OrgMemberAuditAggregateIDCol  = "aggregate_id"
+	OrgMemberAuditSequenceCol     = "sequence"
+	OrgMemberAuditCreationDate    = "creation_date"
+	OrgMemberAuditEventTypeCol    = "event_type"
+	OrgMemberAuditActorCol        = "actor_id"
+	OrgMemberAuditTargetUserCol   = "target_user_id"
+	OrgMemberAuditRolesAddedCol   = "roles_added"
+	OrgMemberAuditRolesRemovedCol = "roles_removed"
+
+	orgRemovedAuditEventType eventstore.EventType = "org.removed"
+)
+
+// orgMemberAuditProjection turns every org.Member* event (and the removal
+// of the org itself) into an append-only audit row: who (actor) did what
+// to whom (target user), and which roles were added/removed. Unlike
+// orgMemberProjection it never updates or deletes a row - every event adds
+// exactly one.
+type orgMemberAuditProjection struct {
+	handler.StatementHandler
+}
+
+func (p *orgMemberAuditProjection) reduceAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.MemberAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-4n8f1", "reduce.wrong.event.type %s", org.MemberAddedEventType)
+	}
+
+	return p.NewCreateStatement(e, p.entryColumns(e, e.UserID, org.MemberAddedEventType, e.Roles, nil))
+}
+
+func (p *orgMemberAuditProjection) reduceChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.MemberChangedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-4n8f2", "reduce.wrong.event.type %s", org.MemberChangedEventType)
+	}
+
+	before, err := p.previousRoles(context.Background(), e.Aggregate().ID, e.UserID, e.Sequence())
+	if err != nil {
+		return nil, err
+	}
+	diff := DiffRoles(before, e.Roles)
+
+	return p.NewCreateStatement(e, p.entryColumns(e, e.UserID, org.MemberChangedEventType, diff.Added, diff.Removed))
+}
+
+func (p *orgMemberAuditProjection) reduceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.MemberRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-4n8f3", "reduce.wrong.event.type %s", org.MemberRemovedEventType)
+	}
+
+	before, err := p.previousRoles(context.Background(), e.Aggregate().ID, e.UserID, e.Sequence())
+	if err != nil {
+		return nil, err
+	}
+
+	return p.NewCreateStatement(e, p.entryColumns(e, e.UserID, org.MemberRemovedEventType, nil, before))
+}
+
+func (p *orgMemberAuditProjection) reduceCascadeRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.MemberCascadeRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-4n8f4", "reduce.wrong.event.type %s", org.MemberCascadeRemovedEventType)
+	}
+
+	before, err := p.previousRoles(context.Background(), e.Aggregate().ID, e.UserID, e.Sequence())
+	if err != nil {
+		return nil, err
+	}
+
+	return p.NewCreateStatement(e, p.entryColumns(e, e.UserID, org.MemberCascadeRemovedEventType, nil, before))
+}
+
+// reduceOrgRemoved writes a single "org removed" audit entry rather than
+// one per member: the org (and every membership it owns) is gone in one
+// event, so that is what the audit trail should show - not a flood of
+// synthetic per-member removals the org itself never actually raised.
+func (p *orgMemberAuditProjection) reduceOrgRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-4n8f5", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+
+	return p.NewCreateStatement(e, p.entryColumns(e, "", orgRemovedAuditEventType, nil, nil))
+}
+
+func (p *orgMemberAuditProjection) entryColumns(event eventstore.Event, targetUserID string, eventType eventstore.EventType, rolesAdded, rolesRemoved []string) []handler.Column {
+	return []handler.Column{
+		handler.NewCol(OrgMemberAuditInstanceIDCol, event.Aggregate().InstanceID),
+		handler.NewCol(OrgMemberAuditAggregateIDCol, event.Aggregate().ID),
+		handler.NewCol(OrgMemberAuditSequenceCol, event.Sequence()),
+		handler.NewCol(OrgMemberAuditCreationDate, event.CreationDate()),
+		handler.NewCol(OrgMemberAuditEventTypeCol, eventType),
+		handler.NewCol(OrgMemberAuditActorCol, event.EditorUser()),
+		handler.NewCol(OrgMemberAuditTargetUserCol, targetUserID),
+		handler.NewCol(OrgMemberAuditRolesAddedCol, database.StringArray(rolesAdded)),
+		handler.NewCol(OrgMemberAuditRolesRemovedCol, database.StringArray(rolesRemoved)),
+	}
+}
+
+// previousRoles replays the org's own member events to find the role set
+// userID had immediately before beforeSequence, so Changed/Removed events -
+// which only carry the new or no role set - can still be diffed against
+// what came before.
+func (p *orgMemberAuditProjection) previousRoles(ctx context.Context, aggregateID, userID string, beforeSequence uint64) ([]string, error) {
+	events, err := p.Eventstore.Filter(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(org.AggregateType).
+		AggregateIDs(aggregateID).
+		Builder(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var roles []string
+	for _, event := range events {
+		if event.Sequence() >= beforeSequence {
+			continue
+		}
+		switch e := event.(type) {
+		case *org.MemberAddedEvent:
+			if e.UserID == userID {
+				roles = e.Roles
+			}
+		case *org.MemberChangedEvent:
+			if e.UserID == userID {
+				roles = e.Roles
+			}
+		}
+	}
+	return roles, nil
+}
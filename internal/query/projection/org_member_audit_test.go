@@ -0,0 +1,189 @@
+package projection
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+func TestOrgMemberAuditProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "org.MemberAddedType",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.MemberAddedEventType),
+					org.AggregateType,
+					[]byte(`{
+					"userId": "user-id",
+					"roles": ["role"]
+				}`),
+				), org.MemberAddedEventMapper),
+			},
+			reduce: (&orgMemberAuditProjection{}).reduceAdded,
+			want: wantReduce{
+				aggregateType:    org.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				projection:       OrgMemberAuditProjectionTable,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.org_member_audit_log (instance_id, aggregate_id, sequence, creation_date, event_type, actor_id, target_user_id, roles_added, roles_removed) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+								uint64(15),
+								anyArg{},
+								org.MemberAddedEventType,
+								anyArg{},
+								"user-id",
+								database.StringArray{"role"},
+								database.StringArray(nil),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org.MemberChangedType",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.MemberChangedEventType),
+					org.AggregateType,
+					[]byte(`{
+					"userId": "user-id",
+					"roles": ["role", "changed"]
+				}`),
+				), org.MemberChangedEventMapper),
+			},
+			reduce: (&orgMemberAuditProjection{
+				StatementHandler: getStatementHandlerWithFilters(
+					org.NewMemberAddedEvent(context.Background(),
+						&org.NewAggregate("agg-id", "agg-id").Aggregate,
+						"user-id",
+						"role",
+					),
+				)(t)}).reduceChanged,
+			want: wantReduce{
+				aggregateType:    org.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				projection:       OrgMemberAuditProjectionTable,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.org_member_audit_log (instance_id, aggregate_id, sequence, creation_date, event_type, actor_id, target_user_id, roles_added, roles_removed) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+								uint64(15),
+								anyArg{},
+								org.MemberChangedEventType,
+								anyArg{},
+								"user-id",
+								database.StringArray{"changed"},
+								database.StringArray(nil),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org.OrgRemovedType writes a single audit entry",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(org.OrgRemovedEventType),
+					org.AggregateType,
+					[]byte(`{}`),
+				), org.OrgRemovedEventMapper),
+			},
+			reduce: (&orgMemberAuditProjection{}).reduceOrgRemoved,
+			want: wantReduce{
+				aggregateType:    org.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				projection:       OrgMemberAuditProjectionTable,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.org_member_audit_log (instance_id, aggregate_id, sequence, creation_date, event_type, actor_id, target_user_id, roles_added, roles_removed) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+								uint64(15),
+								anyArg{},
+								orgRemovedAuditEventType,
+								anyArg{},
+								"",
+								database.StringArray(nil),
+								database.StringArray(nil),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if _, ok := err.(errors.InvalidArgument); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, tt.want)
+		})
+	}
+
+	t.Run("org.OrgRemovedType writes exactly one audit row, not one per member", func(t *testing.T) {
+		event := getEvent(testEvent(
+			repository.EventType(org.OrgRemovedEventType),
+			org.AggregateType,
+			[]byte(`{}`),
+		), org.OrgRemovedEventMapper)(t)
+
+		got, err := (&orgMemberAuditProjection{}).reduceOrgRemoved(event)
+		if err != nil {
+			t.Fatalf("reduceOrgRemoved: %v", err)
+		}
+
+		executer := &countingExecuter{}
+		if err := got.Execute(executer, OrgMemberAuditProjectionTable); err != nil {
+			t.Fatalf("execute: %v", err)
+		}
+		if executer.count != 1 {
+			t.Errorf("want exactly 1 audit row for an org removal, got %d", executer.count)
+		}
+	})
+}
+
+// countingExecuter counts the statements run against it, accepting any.
+type countingExecuter struct {
+	count int
+}
+
+func (c *countingExecuter) Exec(string, ...interface{}) (sql.Result, error) {
+	c.count++
+	return nil, nil
+}
@@ -0,0 +1,132 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+const (
+	ProjectMemberAuditProjectionTable = "projections.project_member_audit_log"
+
+	ProjectMemberAuditInstanceIDCol   = "instance_id"
+	ProjectMemberAuditAggregateIDCol  = "aggregate_id"
+	ProjectMemberAuditSequenceCol     = "sequence"
+	ProjectMemberAuditCreationDate    = "creation_date"
+	ProjectMemberAuditEventTypeCol    = "event_type"
+	ProjectMemberAuditActorCol        = "actor_id"
+	ProjectMemberAuditTargetUserCol   = "target_user_id"
+	ProjectMemberAuditRolesAddedCol   = "roles_added"
+	ProjectMemberAuditRolesRemovedCol = "roles_removed"
+)
+
+// projectMemberAuditProjection mirrors orgMemberAuditProjection for
+// project.Member* events: every add/change/remove becomes one append-only
+// audit row, with the before/after role diff for Changed events computed
+// by the same DiffRoles helper.
+type projectMemberAuditProjection struct {
+	handler.StatementHandler
+}
+
+func (p *projectMemberAuditProjection) reduceAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.MemberAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-5n8f1", "reduce.wrong.event.type %s", project.MemberAddedEventType)
+	}
+
+	return p.NewCreateStatement(e, p.entryColumns(e, e.UserID, project.MemberAddedEventType, e.Roles, nil))
+}
+
+func (p *projectMemberAuditProjection) reduceChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.MemberChangedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-5n8f2", "reduce.wrong.event.type %s", project.MemberChangedEventType)
+	}
+
+	before, err := p.previousRoles(context.Background(), e.Aggregate().ID, e.UserID, e.Sequence())
+	if err != nil {
+		return nil, err
+	}
+	diff := DiffRoles(before, e.Roles)
+
+	return p.NewCreateStatement(e, p.entryColumns(e, e.UserID, project.MemberChangedEventType, diff.Added, diff.Removed))
+}
+
+func (p *projectMemberAuditProjection) reduceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.MemberRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-5n8f3", "reduce.wrong.event.type %s", project.MemberRemovedEventType)
+	}
+
+	before, err := p.previousRoles(context.Background(), e.Aggregate().ID, e.UserID, e.Sequence())
+	if err != nil {
+		return nil, err
+	}
+
+	return p.NewCreateStatement(e, p.entryColumns(e, e.UserID, project.MemberRemovedEventType, nil, before))
+}
+
+func (p *projectMemberAuditProjection) reduceCascadeRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.MemberCascadeRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-5n8f4", "reduce.wrong.event.type %s", project.MemberCascadeRemovedEventType)
+	}
+
+	before, err := p.previousRoles(context.Background(), e.Aggregate().ID, e.UserID, e.Sequence())
+	if err != nil {
+		return nil, err
+	}
+
+	return p.NewCreateStatement(e, p.entryColumns(e, e.UserID, project.MemberCascadeRemovedEventType, nil, before))
+}
+
+func (p *projectMemberAuditProjection) entryColumns(event eventstore.Event, targetUserID string, eventType eventstore.EventType, rolesAdded, rolesRemoved []string) []handler.Column {
+	return []handler.Column{
+		handler.NewCol(ProjectMemberAuditInstanceIDCol, event.Aggregate().InstanceID),
+		handler.NewCol(ProjectMemberAuditAggregateIDCol, event.Aggregate().ID),
+		handler.NewCol(ProjectMemberAuditSequenceCol, event.Sequence()),
+		handler.NewCol(ProjectMemberAuditCreationDate, event.CreationDate()),
+		handler.NewCol(ProjectMemberAuditEventTypeCol, eventType),
+		handler.NewCol(ProjectMemberAuditActorCol, event.EditorUser()),
+		handler.NewCol(ProjectMemberAuditTargetUserCol, targetUserID),
+		handler.NewCol(ProjectMemberAuditRolesAddedCol, database.StringArray(rolesAdded)),
+		handler.NewCol(ProjectMemberAuditRolesRemovedCol, database.StringArray(rolesRemoved)),
+	}
+}
+
+// previousRoles mirrors orgMemberAuditProjection.previousRoles: it replays
+// the project's own member events to recover the role set userID had
+// immediately before beforeSequence.
+func (p *projectMemberAuditProjection) previousRoles(ctx context.Context, aggregateID, userID string, beforeSequence uint64) ([]string, error) {
+	events, err := p.Eventstore.Filter(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(project.AggregateType).
+		AggregateIDs(aggregateID).
+		Builder(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var roles []string
+	for _, event := range events {
+		if event.Sequence() >= beforeSequence {
+			continue
+		}
+		switch e := event.(type) {
+		case *project.MemberAddedEvent:
+			if e.UserID == userID {
+				roles = e.Roles
+			}
+		case *project.MemberChangedEvent:
+			if e.UserID == userID {
+				roles = e.Roles
+			}
+		}
+	}
+	return roles, nil
+}
@@ -0,0 +1,166 @@
+package projection
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+func TestProjectMemberAuditProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "project.MemberAddedType",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(project.MemberAddedEventType),
+					project.AggregateType,
+					[]byte(`{
+					"userId": "user-id",
+					"roles": ["role"]
+				}`),
+				), project.MemberAddedEventMapper),
+			},
+			reduce: (&projectMemberAuditProjection{}).reduceAdded,
+			want: wantReduce{
+				aggregateType:    project.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				projection:       ProjectMemberAuditProjectionTable,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.project_member_audit_log (instance_id, aggregate_id, sequence, creation_date, event_type, actor_id, target_user_id, roles_added, roles_removed) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+								uint64(15),
+								anyArg{},
+								project.MemberAddedEventType,
+								anyArg{},
+								"user-id",
+								database.StringArray{"role"},
+								database.StringArray(nil),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "project.MemberChangedType",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(project.MemberChangedEventType),
+					project.AggregateType,
+					[]byte(`{
+					"userId": "user-id",
+					"roles": ["role", "changed"]
+				}`),
+				), project.MemberChangedEventMapper),
+			},
+			reduce: (&projectMemberAuditProjection{
+				StatementHandler: getStatementHandlerWithFilters(
+					project.NewMemberAddedEvent(context.Background(),
+						&project.NewAggregate("agg-id", "agg-id").Aggregate,
+						"user-id",
+						"role",
+					),
+				)(t)}).reduceChanged,
+			want: wantReduce{
+				aggregateType:    project.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				projection:       ProjectMemberAuditProjectionTable,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.project_member_audit_log (instance_id, aggregate_id, sequence, creation_date, event_type, actor_id, target_user_id, roles_added, roles_removed) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+								uint64(15),
+								anyArg{},
+								project.MemberChangedEventType,
+								anyArg{},
+								"user-id",
+								database.StringArray{"changed"},
+								database.StringArray(nil),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "project.MemberRemovedType",
+			args: args{
+				event: getEvent(testEvent(
+					repository.EventType(project.MemberRemovedEventType),
+					project.AggregateType,
+					[]byte(`{
+					"userId": "user-id"
+				}`),
+				), project.MemberRemovedEventMapper),
+			},
+			reduce: (&projectMemberAuditProjection{
+				StatementHandler: getStatementHandlerWithFilters(
+					project.NewMemberAddedEvent(context.Background(),
+						&project.NewAggregate("agg-id", "agg-id").Aggregate,
+						"user-id",
+						"role",
+					),
+				)(t)}).reduceRemoved,
+			want: wantReduce{
+				aggregateType:    project.AggregateType,
+				sequence:         15,
+				previousSequence: 10,
+				projection:       ProjectMemberAuditProjectionTable,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.project_member_audit_log (instance_id, aggregate_id, sequence, creation_date, event_type, actor_id, target_user_id, roles_added, roles_removed) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+								uint64(15),
+								anyArg{},
+								project.MemberRemovedEventType,
+								anyArg{},
+								"user-id",
+								database.StringArray(nil),
+								database.StringArray{"role"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if _, ok := err.(errors.InvalidArgument); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, tt.want)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package projection
+
+import (
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/crdb"
+	handlermongo "github.com/zitadel/zitadel/internal/eventstore/handler/mongo"
+)
+
+// StoreMongoDB selects the mongo StatementBuilder in Config.Store; any
+// other value (including the empty string) keeps the crdb default.
+const StoreMongoDB = "mongodb"
+
+// Config mirrors the `Projections` block of defaults.yaml: which backend
+// every projection's StatementBuilder writes to.
+type Config struct {
+	Store string
+}
+
+// Start configures handler.DefaultStatementBuilder for every projection
+// according to config, so operators can opt into MongoDB instead of always
+// getting whatever the crdb package's own init() registered. mongoDB is
+// only used (and so only needs to be non-nil) when config.Store is
+// StoreMongoDB.
+func Start(config Config, mongoDB *mongo.Database) {
+	if config.Store != StoreMongoDB {
+		handler.DefaultStatementBuilder = crdb.NewBuilder()
+		return
+	}
+	handler.DefaultStatementBuilder = handlermongo.NewBuilder(handlermongo.WrapDatabase(mongoDB))
+}
@@ -0,0 +1,216 @@
+package idpconfig
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/caos/zitadel/internal/domain"
+	"github.com/caos/zitadel/internal/errors"
+	"github.com/caos/zitadel/internal/eventstore"
+	"github.com/caos/zitadel/internal/eventstore/repository"
+)
+
+const (
+	JWTConfigAddedEventType   = idpConfigEventTypePrefix + "config.jwt.added"
+	JWTConfigChangedEventType = idpConfigEventTypePrefix + "config.jwt.changed"
+)
+
+// JWTConfigAddedEvent is raised when a JWT IDP is added to an org/instance.
+// Besides the issuer/keys endpoint ZITADEL needs to verify a token, it
+// carries the policy operators use to harden that verification: how often
+// the JWKS is refreshed, which signing algorithms are trusted, and the
+// audience/claims/leeway every incoming token is checked against.
+type JWTConfigAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	IDPConfigID  string `json:"idpConfigId"`
+	Issuer       string `json:"issuer"`
+	KeysEndpoint string `json:"keysEndpoint"`
+
+	// JWKSRefreshInterval is how often the JWKS is re-fetched from
+	// KeysEndpoint. JWKSMaxAge caps how long a cached JWKS may be reused if
+	// KeysEndpoint becomes unreachable.
+	JWKSRefreshInterval time.Duration `json:"jwksRefreshInterval,omitempty"`
+	JWKSMaxAge          time.Duration `json:"jwksMaxAge,omitempty"`
+
+	// AllowedSigningAlgorithms is the set of JWS "alg" values ZITADEL will
+	// accept; a token signed with any other algorithm is rejected before
+	// signature verification even runs.
+	AllowedSigningAlgorithms []domain.JWTSigningAlgorithm `json:"allowedSigningAlgorithms,omitempty"`
+
+	Audience       string            `json:"audience,omitempty"`
+	RequiredClaims map[string]string `json:"requiredClaims,omitempty"`
+
+	// ClockSkewLeeway is the tolerance applied to a token's exp/nbf claims.
+	ClockSkewLeeway time.Duration `json:"clockSkewLeeway,omitempty"`
+}
+
+func NewJWTConfigAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	idpConfigID,
+	issuer,
+	keysEndpoint string,
+	jwksRefreshInterval,
+	jwksMaxAge time.Duration,
+	allowedSigningAlgorithms []domain.JWTSigningAlgorithm,
+	audience string,
+	requiredClaims map[string]string,
+	clockSkewLeeway time.Duration,
+) *JWTConfigAddedEvent {
+	return &JWTConfigAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			JWTConfigAddedEventType,
+		),
+		IDPConfigID:              idpConfigID,
+		Issuer:                   issuer,
+		KeysEndpoint:             keysEndpoint,
+		JWKSRefreshInterval:      jwksRefreshInterval,
+		JWKSMaxAge:               jwksMaxAge,
+		AllowedSigningAlgorithms: allowedSigningAlgorithms,
+		Audience:                 audience,
+		RequiredClaims:           requiredClaims,
+		ClockSkewLeeway:          clockSkewLeeway,
+	}
+}
+
+func (e *JWTConfigAddedEvent) Data() interface{} {
+	return e
+}
+
+func (e *JWTConfigAddedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func JWTConfigAddedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &JWTConfigAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, eventstore.ThrowInternal(err, "IDP-2n8fs", "unable to unmarshal jwt config added")
+	}
+
+	return e, nil
+}
+
+// JWTConfigChangedEvent carries only the fields that were actually changed;
+// every pointer/nil-slice field left unset by the caller must be ignored by
+// the reducer.
+type JWTConfigChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	IDPConfigID string `json:"idpConfigId"`
+
+	Issuer       *string `json:"issuer,omitempty"`
+	KeysEndpoint *string `json:"keysEndpoint,omitempty"`
+
+	JWKSRefreshInterval *time.Duration `json:"jwksRefreshInterval,omitempty"`
+	JWKSMaxAge          *time.Duration `json:"jwksMaxAge,omitempty"`
+
+	AllowedSigningAlgorithms *[]domain.JWTSigningAlgorithm `json:"allowedSigningAlgorithms,omitempty"`
+
+	Audience       *string            `json:"audience,omitempty"`
+	RequiredClaims *map[string]string `json:"requiredClaims,omitempty"`
+
+	ClockSkewLeeway *time.Duration `json:"clockSkewLeeway,omitempty"`
+}
+
+func NewJWTConfigChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	idpConfigID string,
+	changes []JWTConfigChanges,
+) (*JWTConfigChangedEvent, error) {
+	if len(changes) == 0 {
+		return nil, errors.ThrowPreconditionFailed(nil, "IDP-2m9fJ", "Errors.NoChangesFound")
+	}
+	changeEvent := &JWTConfigChangedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			JWTConfigChangedEventType,
+		),
+		IDPConfigID: idpConfigID,
+	}
+	for _, change := range changes {
+		change(changeEvent)
+	}
+	return changeEvent, nil
+}
+
+// JWTConfigChanges is applied by NewJWTConfigChangedEvent, mirroring the
+// functional-option style ZITADEL's other *ChangedEvent constructors use to
+// keep partial updates additive.
+type JWTConfigChanges func(event *JWTConfigChangedEvent)
+
+func ChangeIssuer(issuer string) func(*JWTConfigChangedEvent) {
+	return func(e *JWTConfigChangedEvent) {
+		e.Issuer = &issuer
+	}
+}
+
+func ChangeKeysEndpoint(keysEndpoint string) func(*JWTConfigChangedEvent) {
+	return func(e *JWTConfigChangedEvent) {
+		e.KeysEndpoint = &keysEndpoint
+	}
+}
+
+func ChangeJWKSRefreshInterval(interval time.Duration) func(*JWTConfigChangedEvent) {
+	return func(e *JWTConfigChangedEvent) {
+		e.JWKSRefreshInterval = &interval
+	}
+}
+
+func ChangeJWKSMaxAge(maxAge time.Duration) func(*JWTConfigChangedEvent) {
+	return func(e *JWTConfigChangedEvent) {
+		e.JWKSMaxAge = &maxAge
+	}
+}
+
+func ChangeAllowedSigningAlgorithms(algorithms []domain.JWTSigningAlgorithm) func(*JWTConfigChangedEvent) {
+	return func(e *JWTConfigChangedEvent) {
+		e.AllowedSigningAlgorithms = &algorithms
+	}
+}
+
+func ChangeAudience(audience string) func(*JWTConfigChangedEvent) {
+	return func(e *JWTConfigChangedEvent) {
+		e.Audience = &audience
+	}
+}
+
+func ChangeRequiredClaims(requiredClaims map[string]string) func(*JWTConfigChangedEvent) {
+	return func(e *JWTConfigChangedEvent) {
+		e.RequiredClaims = &requiredClaims
+	}
+}
+
+func ChangeClockSkewLeeway(leeway time.Duration) func(*JWTConfigChangedEvent) {
+	return func(e *JWTConfigChangedEvent) {
+		e.ClockSkewLeeway = &leeway
+	}
+}
+
+func (e *JWTConfigChangedEvent) Data() interface{} {
+	return e
+}
+
+func (e *JWTConfigChangedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func JWTConfigChangedEventMapper(event *repository.Event) (eventstore.Event, error) {
+	e := &JWTConfigChangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := json.Unmarshal(event.Data, e)
+	if err != nil {
+		return nil, eventstore.ThrowInternal(err, "IDP-3m8fs", "unable to unmarshal jwt config changed")
+	}
+
+	return e, nil
+}